@@ -0,0 +1,663 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	groupMemberTable = table{
+		name:          projection.GroupMemberProjectionTable,
+		instanceIDCol: projection.GroupMemberInstanceIDCol,
+	}
+	groupMembersAlias = groupMemberTable.setAlias("members")
+
+	GroupMemberCreationDate = Column{
+		name:  projection.GroupMemberCreationDate,
+		table: groupMembersAlias,
+	}
+	GroupMemberChangeDate = Column{
+		name:  projection.GroupMemberChangeDate,
+		table: groupMembersAlias,
+	}
+	GroupMemberSequence = Column{
+		name:  projection.GroupMemberSequence,
+		table: groupMembersAlias,
+	}
+	GroupMemberResourceOwner = Column{
+		name:  projection.GroupMemberResourceOwner,
+		table: groupMembersAlias,
+	}
+	GroupMemberUserID = Column{
+		name:  projection.GroupMemberUserIDCol,
+		table: groupMembersAlias,
+	}
+	GroupMemberGroupID = Column{
+		name:  projection.GroupMemberGroupIDCol,
+		table: groupMembersAlias,
+	}
+	GroupMemberUUID = Column{
+		name:  projection.GroupMemberUUIDCol,
+		table: groupMembersAlias,
+	}
+	GroupMemberRoles = Column{
+		name:  projection.GroupMemberRolesCol,
+		table: groupMembersAlias,
+	}
+	GroupMemberInstanceID = Column{
+		name:  projection.GroupMemberInstanceIDCol,
+		table: groupMembersAlias,
+	}
+)
+
+// maxGroupHierarchyDepth bounds the WITH RECURSIVE traversal of
+// projections.group_group_members so a cyclic parent/child chain cannot
+// loop indefinitely.
+const maxGroupHierarchyDepth = 32
+
+var groupGroupMembersTable = table{
+	name:          projection.GroupGroupMembersProjectionTable,
+	instanceIDCol: projection.GroupGroupMembersInstanceIDCol,
+}
+
+// GroupMember represents a single user's membership in a group, enriched
+// with the subset of user display data needed for listing purposes.
+type GroupMember struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	UserID        string
+	GroupID       string
+	// UUID is a stable identifier for the membership that never changes,
+	// even if the group or membership is renamed or restructured. It is
+	// generated once when the membership is created and is the preferred
+	// handle for external systems such as SCIM clients, audit logs and IdP
+	// sync jobs.
+	UUID  string
+	Roles database.TextArray[string]
+	// InheritedFromGroupID is set when the membership was resolved
+	// transitively in GroupMembersQuery.Recursive mode, i.e. the user is a
+	// direct member of some group nested under GroupMembersQuery.GroupID
+	// rather than of GroupMembersQuery.GroupID itself. It holds that root
+	// GroupID the traversal was seeded with, so callers can tell "this came
+	// through the requested group's hierarchy" apart from "this is a
+	// direct member of it." It is empty for direct members.
+	InheritedFromGroupID string
+
+	PreferredLoginName string
+	Email              string
+	FirstName          string
+	LastName           string
+	DisplayName        string
+	AvatarURL          string
+	UserType           domain.UserType
+}
+
+type GroupMembers struct {
+	SearchResponse
+	GroupMembers []*GroupMember
+}
+
+// MemberField identifies a single piece of user display data that can be
+// requested via GroupMembersQuery.Expand.
+type MemberField int32
+
+const (
+	MemberFieldDisplayName MemberField = iota
+	MemberFieldEmail
+	MemberFieldAvatarURL
+)
+
+type GroupMembersQuery struct {
+	SearchRequest
+	Queries []SearchQuery
+	// WithGroups, when true, joins the user projections to enrich each
+	// GroupMember with display data (login name, email, names, avatar,
+	// user type). Callers that only need the user ID and roles should
+	// leave this false to avoid the joins against projections.users13_humans,
+	// projections.users13_machines and projections.login_names3.
+	WithGroups bool
+	// Expand implies WithGroups. It is reserved for restricting the join to
+	// a subset of user fields; today any non-empty Expand behaves like
+	// WithGroups set with all fields expanded.
+	Expand []MemberField
+	// IncludeRoles controls whether the members.roles array is part of the
+	// result. Lower-privileged consumers, e.g. listing members from
+	// another org, should leave this false so role information (and its
+	// payload weight) is excluded from bulk listings.
+	IncludeRoles bool
+	// Recursive, when true, resolves the transitive closure of members
+	// across nested groups (groups-in-groups) instead of only the
+	// requested group's direct members. GroupID is the root of that
+	// traversal and is required when Recursive is set.
+	Recursive bool
+	GroupID   string
+}
+
+// expandUsers reports whether the user projections should be joined to
+// enrich the result with display data.
+func (q *GroupMembersQuery) expandUsers() bool {
+	return q.WithGroups || len(q.Expand) > 0
+}
+
+func (q *GroupMembersQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// NewGroupMemberUUIDSearchQuery allows looking up a group member by its
+// stable UUID, as an alternative to the composite
+// (instance, group, user) key.
+func NewGroupMemberUUIDSearchQuery(uuid string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberUUID, uuid, TextEquals)
+}
+
+func NewGroupMemberGroupIDSearchQuery(groupID string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberGroupID, groupID, TextEquals)
+}
+
+func NewGroupMemberUserIDSearchQuery(userID string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberUserID, userID, TextEquals)
+}
+
+func prepareGroupMembersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberCreationDate.identifier(),
+			GroupMemberChangeDate.identifier(),
+			GroupMemberSequence.identifier(),
+			GroupMemberResourceOwner.identifier(),
+			GroupMemberUserID.identifier(),
+			GroupMemberGroupID.identifier(),
+			GroupMemberUUID.identifier(),
+			GroupMemberRoles.identifier(),
+			LoginNameNameCol.identifier(),
+			HumanEmailCol.identifier(),
+			HumanFirstNameCol.identifier(),
+			HumanLastNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			MachineNameCol.identifier(),
+			HumanAvatarURLCol.identifier(),
+			UserTypeCol.identifier(),
+			countColumn.identifier(),
+		).From(groupMembersAlias.identifier()).
+			LeftJoin(join(HumanUserIDCol, GroupMemberUserID)).
+			LeftJoin(join(MachineUserIDCol, GroupMemberUserID)).
+			LeftJoin(join(UserIDCol, GroupMemberUserID)).
+			LeftJoin(join(LoginNameUserIDCol, GroupMemberUserID) + db.Timetravel(call.Took(ctx))).
+			Where(sq.Eq{
+				LoginNameIsPrimaryCol.identifier(): true,
+			}).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			groupMembers := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				groupMember := new(GroupMember)
+				var (
+					preferredLoginName = sql.NullString{}
+					email              = sql.NullString{}
+					firstName          = sql.NullString{}
+					lastName           = sql.NullString{}
+					displayName        = sql.NullString{}
+					machineName        = sql.NullString{}
+					avatarKey          = sql.NullString{}
+					userType           = sql.NullInt32{}
+				)
+				err := rows.Scan(
+					&groupMember.CreationDate,
+					&groupMember.ChangeDate,
+					&groupMember.Sequence,
+					&groupMember.ResourceOwner,
+					&groupMember.UserID,
+					&groupMember.GroupID,
+					&groupMember.UUID,
+					&groupMember.Roles,
+					&preferredLoginName,
+					&email,
+					&firstName,
+					&lastName,
+					&displayName,
+					&machineName,
+					&avatarKey,
+					&userType,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groupMember.PreferredLoginName = preferredLoginName.String
+				groupMember.Email = email.String
+				groupMember.FirstName = firstName.String
+				groupMember.LastName = lastName.String
+				if displayName.Valid {
+					groupMember.DisplayName = displayName.String
+				} else {
+					groupMember.DisplayName = machineName.String
+				}
+				groupMember.AvatarURL = avatarKey.String
+				groupMember.UserType = domain.UserType(userType.Int32)
+
+				groupMembers = append(groupMembers, groupMember)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-xOpl3", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				GroupMembers: groupMembers,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+// prepareGroupMembersIDsOnlyQuery builds the narrow SELECT used when the
+// caller only needs IDs and roles, skipping the joins against
+// projections.users13_humans, projections.users13_machines and
+// projections.login_names3 entirely.
+func prepareGroupMembersIDsOnlyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberCreationDate.identifier(),
+			GroupMemberChangeDate.identifier(),
+			GroupMemberSequence.identifier(),
+			GroupMemberResourceOwner.identifier(),
+			GroupMemberUserID.identifier(),
+			GroupMemberGroupID.identifier(),
+			GroupMemberUUID.identifier(),
+			GroupMemberRoles.identifier(),
+			countColumn.identifier(),
+		).From(groupMembersAlias.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			groupMembers := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				groupMember := new(GroupMember)
+				err := rows.Scan(
+					&groupMember.CreationDate,
+					&groupMember.ChangeDate,
+					&groupMember.Sequence,
+					&groupMember.ResourceOwner,
+					&groupMember.UserID,
+					&groupMember.GroupID,
+					&groupMember.UUID,
+					&groupMember.Roles,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groupMembers = append(groupMembers, groupMember)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-ieN3a", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				GroupMembers: groupMembers,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+// prepareGroupMembersNoRolesQuery is prepareGroupMembersQuery with the
+// members.roles column omitted from the SELECT and the returned
+// GroupMember.Roles left unset.
+func prepareGroupMembersNoRolesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberCreationDate.identifier(),
+			GroupMemberChangeDate.identifier(),
+			GroupMemberSequence.identifier(),
+			GroupMemberResourceOwner.identifier(),
+			GroupMemberUserID.identifier(),
+			GroupMemberGroupID.identifier(),
+			GroupMemberUUID.identifier(),
+			LoginNameNameCol.identifier(),
+			HumanEmailCol.identifier(),
+			HumanFirstNameCol.identifier(),
+			HumanLastNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			MachineNameCol.identifier(),
+			HumanAvatarURLCol.identifier(),
+			UserTypeCol.identifier(),
+			countColumn.identifier(),
+		).From(groupMembersAlias.identifier()).
+			LeftJoin(join(HumanUserIDCol, GroupMemberUserID)).
+			LeftJoin(join(MachineUserIDCol, GroupMemberUserID)).
+			LeftJoin(join(UserIDCol, GroupMemberUserID)).
+			LeftJoin(join(LoginNameUserIDCol, GroupMemberUserID) + db.Timetravel(call.Took(ctx))).
+			Where(sq.Eq{
+				LoginNameIsPrimaryCol.identifier(): true,
+			}).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			groupMembers := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				groupMember := new(GroupMember)
+				var (
+					preferredLoginName = sql.NullString{}
+					email              = sql.NullString{}
+					firstName          = sql.NullString{}
+					lastName           = sql.NullString{}
+					displayName        = sql.NullString{}
+					machineName        = sql.NullString{}
+					avatarKey          = sql.NullString{}
+					userType           = sql.NullInt32{}
+				)
+				err := rows.Scan(
+					&groupMember.CreationDate,
+					&groupMember.ChangeDate,
+					&groupMember.Sequence,
+					&groupMember.ResourceOwner,
+					&groupMember.UserID,
+					&groupMember.GroupID,
+					&groupMember.UUID,
+					&preferredLoginName,
+					&email,
+					&firstName,
+					&lastName,
+					&displayName,
+					&machineName,
+					&avatarKey,
+					&userType,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groupMember.PreferredLoginName = preferredLoginName.String
+				groupMember.Email = email.String
+				groupMember.FirstName = firstName.String
+				groupMember.LastName = lastName.String
+				if displayName.Valid {
+					groupMember.DisplayName = displayName.String
+				} else {
+					groupMember.DisplayName = machineName.String
+				}
+				groupMember.AvatarURL = avatarKey.String
+				groupMember.UserType = domain.UserType(userType.Int32)
+
+				groupMembers = append(groupMembers, groupMember)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Aesh2", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				GroupMembers: groupMembers,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+// prepareGroupMembersIDsOnlyNoRolesQuery is prepareGroupMembersIDsOnlyQuery
+// with the members.roles column omitted.
+func prepareGroupMembersIDsOnlyNoRolesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberCreationDate.identifier(),
+			GroupMemberChangeDate.identifier(),
+			GroupMemberSequence.identifier(),
+			GroupMemberResourceOwner.identifier(),
+			GroupMemberUserID.identifier(),
+			GroupMemberGroupID.identifier(),
+			GroupMemberUUID.identifier(),
+			countColumn.identifier(),
+		).From(groupMembersAlias.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			groupMembers := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				groupMember := new(GroupMember)
+				err := rows.Scan(
+					&groupMember.CreationDate,
+					&groupMember.ChangeDate,
+					&groupMember.Sequence,
+					&groupMember.ResourceOwner,
+					&groupMember.UserID,
+					&groupMember.GroupID,
+					&groupMember.UUID,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groupMembers = append(groupMembers, groupMember)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-sh1Ae", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				GroupMembers: groupMembers,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+// prepareGroupMembersRecursiveQuery resolves the transitive closure of a
+// group's members across nested groups. It seeds a `WITH RECURSIVE` CTE
+// with groupID, walks projections.group_group_members from parent to
+// child, dedups groups reachable through more than one parent path down to
+// their shallowest depth, and joins the resulting set of group IDs against
+// projections.group_members and, when expand is true, the user projections.
+// The traversal is capped at maxGroupHierarchyDepth levels so a cycle in the
+// parent/child links cannot loop indefinitely. expand and includeRoles
+// mirror GroupMembersQuery.expandUsers and GroupMembersQuery.IncludeRoles,
+// so a recursive listing can skip the same joins and columns the
+// non-recursive prepare funcs do.
+func prepareGroupMembersRecursiveQuery(ctx context.Context, db prepareDatabase, groupID, instanceID string, expand, includeRoles bool) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	// group_hierarchy walks parent -> child with UNION ALL (required so the
+	// depth guard can bound the recursion), so a group reachable through
+	// more than one parent path appears once per path. group_hierarchy_dedup
+	// collapses that back down to one row per group_id, keeping the
+	// shallowest depth at which it was reached, before anything is joined
+	// against projections.group_members. The ggm.instance_id predicate is
+	// required inside the recursive arm itself: the CTE body is raw SQL, so
+	// an instance condition added to the outer WHERE (the mechanism every
+	// other prepare func in this file relies on) can never reach in here,
+	// and without it the traversal would walk group_group_members rows
+	// belonging to every instance.
+	groupHierarchyCTE := "WITH RECURSIVE group_hierarchy (group_id, depth) AS (" +
+		"SELECT group_id, 0 FROM (SELECT ? AS group_id) AS root " +
+		"UNION ALL " +
+		"SELECT ggm.child_group_id, group_hierarchy.depth + 1 " +
+		"FROM " + groupGroupMembersTable.name + " AS ggm " +
+		"JOIN group_hierarchy ON ggm.parent_group_id = group_hierarchy.group_id " +
+		"WHERE group_hierarchy.depth < ? AND ggm.instance_id = ?" +
+		"), group_hierarchy_dedup (group_id, depth) AS (" +
+		"SELECT group_id, MIN(depth) FROM group_hierarchy GROUP BY group_id) "
+
+	columns := []string{
+		GroupMemberCreationDate.identifier(),
+		GroupMemberChangeDate.identifier(),
+		GroupMemberSequence.identifier(),
+		GroupMemberResourceOwner.identifier(),
+		GroupMemberUserID.identifier(),
+		GroupMemberGroupID.identifier(),
+		GroupMemberUUID.identifier(),
+	}
+	if includeRoles {
+		columns = append(columns, GroupMemberRoles.identifier())
+	}
+	columns = append(columns, "group_hierarchy_dedup.depth")
+	if expand {
+		columns = append(columns,
+			LoginNameNameCol.identifier(),
+			HumanEmailCol.identifier(),
+			HumanFirstNameCol.identifier(),
+			HumanLastNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			MachineNameCol.identifier(),
+			HumanAvatarURLCol.identifier(),
+			UserTypeCol.identifier(),
+		)
+	}
+	columns = append(columns, countColumn.identifier())
+
+	memberJoin := groupMembersAlias.identifier() + " ON members.group_id = group_hierarchy_dedup.group_id AND " + GroupMemberInstanceID.identifier() + " = ?"
+	if !expand {
+		memberJoin += db.Timetravel(call.Took(ctx))
+	}
+	query := sq.Select(columns...).
+		Prefix(groupHierarchyCTE, groupID, maxGroupHierarchyDepth, instanceID).
+		From("group_hierarchy_dedup").
+		Join(memberJoin, instanceID)
+	if expand {
+		query = query.
+			LeftJoin(join(HumanUserIDCol, GroupMemberUserID)).
+			LeftJoin(join(MachineUserIDCol, GroupMemberUserID)).
+			LeftJoin(join(UserIDCol, GroupMemberUserID)).
+			LeftJoin(join(LoginNameUserIDCol, GroupMemberUserID) + db.Timetravel(call.Took(ctx))).
+			Where(sq.Eq{
+				LoginNameIsPrimaryCol.identifier(): true,
+			})
+	}
+
+	return query.PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			groupMembers := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				groupMember := new(GroupMember)
+				var depth int
+				dest := []interface{}{
+					&groupMember.CreationDate,
+					&groupMember.ChangeDate,
+					&groupMember.Sequence,
+					&groupMember.ResourceOwner,
+					&groupMember.UserID,
+					&groupMember.GroupID,
+					&groupMember.UUID,
+				}
+				if includeRoles {
+					dest = append(dest, &groupMember.Roles)
+				}
+				dest = append(dest, &depth)
+
+				var (
+					preferredLoginName = sql.NullString{}
+					email              = sql.NullString{}
+					firstName          = sql.NullString{}
+					lastName           = sql.NullString{}
+					displayName        = sql.NullString{}
+					machineName        = sql.NullString{}
+					avatarKey          = sql.NullString{}
+					userType           = sql.NullInt32{}
+				)
+				if expand {
+					dest = append(dest,
+						&preferredLoginName,
+						&email,
+						&firstName,
+						&lastName,
+						&displayName,
+						&machineName,
+						&avatarKey,
+						&userType,
+					)
+				}
+				dest = append(dest, &count)
+
+				if err := rows.Scan(dest...); err != nil {
+					return nil, err
+				}
+				if depth > 0 {
+					groupMember.InheritedFromGroupID = groupID
+				}
+				if expand {
+					groupMember.PreferredLoginName = preferredLoginName.String
+					groupMember.Email = email.String
+					groupMember.FirstName = firstName.String
+					groupMember.LastName = lastName.String
+					if displayName.Valid {
+						groupMember.DisplayName = displayName.String
+					} else {
+						groupMember.DisplayName = machineName.String
+					}
+					groupMember.AvatarURL = avatarKey.String
+					groupMember.UserType = domain.UserType(userType.Int32)
+				}
+
+				groupMembers = append(groupMembers, groupMember)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-oodee9", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				GroupMembers: groupMembers,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+// GroupMembers resolves the members of a group. GroupMembersQuery.WithGroups
+// (or a non-empty Expand) decides whether the expensive joins against the
+// user projections are performed; callers that only need IDs and roles
+// should leave both unset. GroupMembersQuery.IncludeRoles decides whether
+// members.roles is part of the result at all. GroupMembersQuery.Recursive
+// resolves the transitive closure across nested groups instead of only
+// direct members.
+func (q *Queries) GroupMembers(ctx context.Context, queries *GroupMembersQuery) (*GroupMembers, error) {
+	if queries.Recursive {
+		if queries.GroupID == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-ahy6O", "Errors.Query.InvalidRequest")
+		}
+		query, scan := prepareGroupMembersRecursiveQuery(ctx, q.client, queries.GroupID, authz.GetInstance(ctx).InstanceID(), queries.expandUsers(), queries.IncludeRoles)
+		stmt, args, err := queries.toQuery(query).ToSql()
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "QUERY-ooz4A", "Errors.Query.InvalidRequest")
+		}
+		rows, err := q.client.QueryContext(ctx, stmt, args...)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "QUERY-ui0Gu", "Errors.Internal")
+		}
+		defer rows.Close()
+		return scan(rows)
+	}
+
+	prepare := prepareGroupMembersIDsOnlyNoRolesQuery
+	switch {
+	case queries.expandUsers() && queries.IncludeRoles:
+		prepare = prepareGroupMembersQuery
+	case queries.expandUsers():
+		prepare = prepareGroupMembersNoRolesQuery
+	case queries.IncludeRoles:
+		prepare = prepareGroupMembersIDsOnlyQuery
+	}
+	query, scan := prepare(ctx, q.client)
+	eq := sq.Eq{GroupMemberInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-ahs4S", "Errors.Query.InvalidRequest")
+	}
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Vaij5", "Errors.Internal")
+	}
+	defer rows.Close()
+	return scan(rows)
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
@@ -8,6 +9,8 @@ import (
 	"regexp"
 	"testing"
 
+	sq "github.com/Masterminds/squirrel"
+
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 )
@@ -20,6 +23,7 @@ var (
 		", members.resource_owner" +
 		", members.user_id" +
 		", members.group_id" +
+		", members.uuid" +
 		", members.roles" +
 		", projections.login_names3.login_name" +
 		", projections.users13_humans.email" +
@@ -52,7 +56,164 @@ var (
 		"resource_owner",
 		"user_id",
 		"group_id",
+		"uuid",
+		"roles",
+		"login_name",
+		"email",
+		"first_name",
+		"last_name",
+		"display_name",
+		"name",
+		"avatar_key",
+		"type",
+		"count",
+	}
+	groupMembersIDsOnlyQuery = regexp.QuoteMeta("SELECT" +
+		" members.creation_date" +
+		", members.change_date" +
+		", members.sequence" +
+		", members.resource_owner" +
+		", members.user_id" +
+		", members.group_id" +
+		", members.uuid" +
+		", members.roles" +
+		", COUNT(*) OVER () " +
+		`FROM projections.group_members AS members AS OF SYSTEM TIME '-1 ms'`)
+	groupMembersIDsOnlyColumns = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"group_id",
+		"uuid",
+		"roles",
+		"count",
+	}
+	groupMembersNoRolesQuery = regexp.QuoteMeta("SELECT" +
+		" members.creation_date" +
+		", members.change_date" +
+		", members.sequence" +
+		", members.resource_owner" +
+		", members.user_id" +
+		", members.group_id" +
+		", members.uuid" +
+		", projections.login_names3.login_name" +
+		", projections.users13_humans.email" +
+		", projections.users13_humans.first_name" +
+		", projections.users13_humans.last_name" +
+		", projections.users13_humans.display_name" +
+		", projections.users13_machines.name" +
+		", projections.users13_humans.avatar_key" +
+		", projections.users13.type" +
+		", COUNT(*) OVER () " +
+		"FROM projections.group_members AS members " +
+		"LEFT JOIN projections.users13_humans " +
+		"ON members.user_id = projections.users13_humans.user_id " +
+		"AND members.instance_id = projections.users13_humans.instance_id " +
+		"LEFT JOIN projections.users13_machines " +
+		"ON members.user_id = projections.users13_machines.user_id " +
+		"AND members.instance_id = projections.users13_machines.instance_id " +
+		"LEFT JOIN projections.users13 " +
+		"ON members.user_id = projections.users13.id " +
+		"AND members.instance_id = projections.users13.instance_id " +
+		"LEFT JOIN projections.login_names3 " +
+		"ON members.user_id = projections.login_names3.user_id " +
+		"AND members.instance_id = projections.login_names3.instance_id " +
+		`AS OF SYSTEM TIME '-1 ms' ` +
+		"WHERE projections.login_names3.is_primary = $1")
+	groupMembersNoRolesColumns = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"group_id",
+		"uuid",
+		"login_name",
+		"email",
+		"first_name",
+		"last_name",
+		"display_name",
+		"name",
+		"avatar_key",
+		"type",
+		"count",
+	}
+	groupMembersIDsOnlyNoRolesQuery = regexp.QuoteMeta("SELECT" +
+		" members.creation_date" +
+		", members.change_date" +
+		", members.sequence" +
+		", members.resource_owner" +
+		", members.user_id" +
+		", members.group_id" +
+		", members.uuid" +
+		", COUNT(*) OVER () " +
+		`FROM projections.group_members AS members AS OF SYSTEM TIME '-1 ms'`)
+	groupMembersIDsOnlyNoRolesColumns = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"group_id",
+		"uuid",
+		"count",
+	}
+	groupMembersRecursiveQuery = regexp.QuoteMeta("WITH RECURSIVE group_hierarchy (group_id, depth) AS (" +
+		"SELECT group_id, 0 FROM (SELECT $1 AS group_id) AS root " +
+		"UNION ALL " +
+		"SELECT ggm.child_group_id, group_hierarchy.depth + 1 " +
+		"FROM projections.group_group_members AS ggm " +
+		"JOIN group_hierarchy ON ggm.parent_group_id = group_hierarchy.group_id " +
+		"WHERE group_hierarchy.depth < $2 AND ggm.instance_id = $3" +
+		"), group_hierarchy_dedup (group_id, depth) AS (" +
+		"SELECT group_id, MIN(depth) FROM group_hierarchy GROUP BY group_id) " +
+		"SELECT" +
+		" members.creation_date" +
+		", members.change_date" +
+		", members.sequence" +
+		", members.resource_owner" +
+		", members.user_id" +
+		", members.group_id" +
+		", members.uuid" +
+		", members.roles" +
+		", group_hierarchy_dedup.depth" +
+		", projections.login_names3.login_name" +
+		", projections.users13_humans.email" +
+		", projections.users13_humans.first_name" +
+		", projections.users13_humans.last_name" +
+		", projections.users13_humans.display_name" +
+		", projections.users13_machines.name" +
+		", projections.users13_humans.avatar_key" +
+		", projections.users13.type" +
+		", COUNT(*) OVER () " +
+		"FROM group_hierarchy_dedup " +
+		"JOIN projections.group_members AS members ON members.group_id = group_hierarchy_dedup.group_id AND members.instance_id = $4 " +
+		"LEFT JOIN projections.users13_humans " +
+		"ON members.user_id = projections.users13_humans.user_id " +
+		"AND members.instance_id = projections.users13_humans.instance_id " +
+		"LEFT JOIN projections.users13_machines " +
+		"ON members.user_id = projections.users13_machines.user_id " +
+		"AND members.instance_id = projections.users13_machines.instance_id " +
+		"LEFT JOIN projections.users13 " +
+		"ON members.user_id = projections.users13.id " +
+		"AND members.instance_id = projections.users13.instance_id " +
+		"LEFT JOIN projections.login_names3 " +
+		"ON members.user_id = projections.login_names3.user_id " +
+		"AND members.instance_id = projections.login_names3.instance_id " +
+		`AS OF SYSTEM TIME '-1 ms' ` +
+		"WHERE projections.login_names3.is_primary = $5")
+	groupMembersRecursiveColumns = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"group_id",
+		"uuid",
 		"roles",
+		"depth",
 		"login_name",
 		"email",
 		"first_name",
@@ -63,6 +224,38 @@ var (
 		"type",
 		"count",
 	}
+	groupMembersRecursiveIDsOnlyNoRolesQuery = regexp.QuoteMeta("WITH RECURSIVE group_hierarchy (group_id, depth) AS (" +
+		"SELECT group_id, 0 FROM (SELECT $1 AS group_id) AS root " +
+		"UNION ALL " +
+		"SELECT ggm.child_group_id, group_hierarchy.depth + 1 " +
+		"FROM projections.group_group_members AS ggm " +
+		"JOIN group_hierarchy ON ggm.parent_group_id = group_hierarchy.group_id " +
+		"WHERE group_hierarchy.depth < $2 AND ggm.instance_id = $3" +
+		"), group_hierarchy_dedup (group_id, depth) AS (" +
+		"SELECT group_id, MIN(depth) FROM group_hierarchy GROUP BY group_id) " +
+		"SELECT" +
+		" members.creation_date" +
+		", members.change_date" +
+		", members.sequence" +
+		", members.resource_owner" +
+		", members.user_id" +
+		", members.group_id" +
+		", members.uuid" +
+		", group_hierarchy_dedup.depth" +
+		", COUNT(*) OVER () " +
+		"FROM group_hierarchy_dedup " +
+		`JOIN projections.group_members AS members ON members.group_id = group_hierarchy_dedup.group_id AND members.instance_id = $4 AS OF SYSTEM TIME '-1 ms'`)
+	groupMembersRecursiveIDsOnlyNoRolesColumns = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"group_id",
+		"uuid",
+		"depth",
+		"count",
+	}
 )
 
 func Test_GroupMemberPrepares(t *testing.T) {
@@ -105,6 +298,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 							"ro",
 							"user-id",
 							"group-id",
+							"member-uuid",
 							database.TextArray[string]{"role-1", "role-2"},
 							"gigi@caos-ag.zitadel.ch",
 							"gigi@caos.ch",
@@ -130,6 +324,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 						ResourceOwner:      "ro",
 						UserID:             "user-id",
 						GroupID:            "group-id",
+						UUID:               "member-uuid",
 						Roles:              database.TextArray[string]{"role-1", "role-2"},
 						PreferredLoginName: "gigi@caos-ag.zitadel.ch",
 						Email:              "gigi@caos.ch",
@@ -157,6 +352,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 							"ro",
 							"user-id",
 							"group-id",
+							"member-uuid",
 							database.TextArray[string]{"role-1", "role-2"},
 							"machine@caos-ag.zitadel.ch",
 							nil,
@@ -182,6 +378,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 						ResourceOwner:      "ro",
 						UserID:             "user-id",
 						GroupID:            "group-id",
+						UUID:               "member-uuid",
 						Roles:              database.TextArray[string]{"role-1", "role-2"},
 						PreferredLoginName: "machine@caos-ag.zitadel.ch",
 						Email:              "",
@@ -209,6 +406,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 							"ro",
 							"user-id-1",
 							"group-id",
+							"member-uuid-1",
 							database.TextArray[string]{"role-1", "role-2"},
 							"gigi@caos-ag.zitadel.ch",
 							"gigi@caos.ch",
@@ -226,6 +424,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 							"ro",
 							"user-id-2",
 							"group-id",
+							"member-uuid-2",
 							database.TextArray[string]{"role-1", "role-2"},
 							"machine@caos-ag.zitadel.ch",
 							nil,
@@ -251,6 +450,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 						ResourceOwner:      "ro",
 						UserID:             "user-id-1",
 						GroupID:            "group-id",
+						UUID:               "member-uuid-1",
 						Roles:              database.TextArray[string]{"role-1", "role-2"},
 						PreferredLoginName: "gigi@caos-ag.zitadel.ch",
 						Email:              "gigi@caos.ch",
@@ -267,6 +467,7 @@ func Test_GroupMemberPrepares(t *testing.T) {
 						ResourceOwner:      "ro",
 						UserID:             "user-id-2",
 						GroupID:            "group-id",
+						UUID:               "member-uuid-2",
 						Roles:              database.TextArray[string]{"role-1", "role-2"},
 						PreferredLoginName: "machine@caos-ag.zitadel.ch",
 						Email:              "",
@@ -279,6 +480,373 @@ func Test_GroupMemberPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareGroupMembersIDsOnlyQuery no result",
+			prepare: prepareGroupMembersIDsOnlyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersIDsOnlyQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &GroupMembers{
+				GroupMembers: []*GroupMember{},
+			},
+		},
+		{
+			name:    "prepareGroupMembersIDsOnlyQuery ids only",
+			prepare: prepareGroupMembersIDsOnlyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersIDsOnlyQuery,
+					groupMembersIDsOnlyColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"group-id",
+							"member-uuid",
+							database.TextArray[string]{"role-1", "role-2"},
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211206,
+						ResourceOwner: "ro",
+						UserID:        "user-id",
+						GroupID:       "group-id",
+						UUID:          "member-uuid",
+						Roles:         database.TextArray[string]{"role-1", "role-2"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupMembersNoRolesQuery human found",
+			prepare: prepareGroupMembersNoRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersNoRolesQuery,
+					groupMembersNoRolesColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"group-id",
+							"member-uuid",
+							"gigi@caos-ag.zitadel.ch",
+							"gigi@caos.ch",
+							"first-name",
+							"last-name",
+							"display name",
+							nil,
+							nil,
+							domain.UserTypeHuman,
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						Sequence:           20211206,
+						ResourceOwner:      "ro",
+						UserID:             "user-id",
+						GroupID:            "group-id",
+						UUID:               "member-uuid",
+						PreferredLoginName: "gigi@caos-ag.zitadel.ch",
+						Email:              "gigi@caos.ch",
+						FirstName:          "first-name",
+						LastName:           "last-name",
+						DisplayName:        "display name",
+						AvatarURL:          "",
+						UserType:           domain.UserTypeHuman,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupMembersIDsOnlyNoRolesQuery ids only",
+			prepare: prepareGroupMembersIDsOnlyNoRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersIDsOnlyNoRolesQuery,
+					groupMembersIDsOnlyNoRolesColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"group-id",
+							"member-uuid",
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211206,
+						ResourceOwner: "ro",
+						UserID:        "user-id",
+						GroupID:       "group-id",
+						UUID:          "member-uuid",
+					},
+				},
+			},
+		},
+		{
+			name: "prepareGroupMembersRecursiveQuery two-level nesting",
+			prepare: func(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+				return prepareGroupMembersRecursiveQuery(ctx, db, "root-group", "instance-id", true, true)
+			},
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersRecursiveQuery,
+					groupMembersRecursiveColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"child-group",
+							"member-uuid",
+							database.TextArray[string]{"role-1", "role-2"},
+							int64(1),
+							"gigi@caos-ag.zitadel.ch",
+							"gigi@caos.ch",
+							"first-name",
+							"last-name",
+							"display name",
+							nil,
+							nil,
+							domain.UserTypeHuman,
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:         testNow,
+						ChangeDate:           testNow,
+						Sequence:             20211206,
+						ResourceOwner:        "ro",
+						UserID:               "user-id",
+						GroupID:              "child-group",
+						UUID:                 "member-uuid",
+						Roles:                database.TextArray[string]{"role-1", "role-2"},
+						InheritedFromGroupID: "root-group",
+						PreferredLoginName:   "gigi@caos-ag.zitadel.ch",
+						Email:                "gigi@caos.ch",
+						FirstName:            "first-name",
+						LastName:             "last-name",
+						DisplayName:          "display name",
+						AvatarURL:            "",
+						UserType:             domain.UserTypeHuman,
+					},
+				},
+			},
+		},
+		{
+			name: "prepareGroupMembersRecursiveQuery direct member",
+			prepare: func(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+				return prepareGroupMembersRecursiveQuery(ctx, db, "root-group", "instance-id", true, true)
+			},
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersRecursiveQuery,
+					groupMembersRecursiveColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"root-group",
+							"member-uuid",
+							database.TextArray[string]{"role-1", "role-2"},
+							int64(0),
+							"gigi@caos-ag.zitadel.ch",
+							"gigi@caos.ch",
+							"first-name",
+							"last-name",
+							"display name",
+							nil,
+							nil,
+							domain.UserTypeHuman,
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						Sequence:           20211206,
+						ResourceOwner:      "ro",
+						UserID:             "user-id",
+						GroupID:            "root-group",
+						UUID:               "member-uuid",
+						Roles:              database.TextArray[string]{"role-1", "role-2"},
+						PreferredLoginName: "gigi@caos-ag.zitadel.ch",
+						Email:              "gigi@caos.ch",
+						FirstName:          "first-name",
+						LastName:           "last-name",
+						DisplayName:        "display name",
+						AvatarURL:          "",
+						UserType:           domain.UserTypeHuman,
+					},
+				},
+			},
+		},
+		{
+			// This only asserts that a row at depth == maxGroupHierarchyDepth
+			// scans correctly through the Go side (column count, depth
+			// parsing, InheritedFromGroupID) — mechanically identical to the
+			// direct-member and two-level-nesting cases above, just a
+			// different depth value. It does NOT exercise the SQL-level
+			// "WHERE group_hierarchy.depth < ?" guard against an actual
+			// parent/child cycle in projections.group_group_members: sqlmock
+			// returns whatever rows we hand it, it doesn't evaluate the CTE.
+			// Confirming the guard itself terminates a real cycle (e.g.
+			// group A nested in group B nested back in group A) needs an
+			// integration test against a recursion-capable engine.
+			name: "prepareGroupMembersRecursiveQuery depth column at the cap scans correctly",
+			prepare: func(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+				return prepareGroupMembersRecursiveQuery(ctx, db, "root-group", "instance-id", true, true)
+			},
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersRecursiveQuery,
+					groupMembersRecursiveColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"deepest-group",
+							"member-uuid",
+							database.TextArray[string]{"role-1", "role-2"},
+							int64(maxGroupHierarchyDepth),
+							"gigi@caos-ag.zitadel.ch",
+							"gigi@caos.ch",
+							"first-name",
+							"last-name",
+							"display name",
+							nil,
+							nil,
+							domain.UserTypeHuman,
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:         testNow,
+						ChangeDate:           testNow,
+						Sequence:             20211206,
+						ResourceOwner:        "ro",
+						UserID:               "user-id",
+						GroupID:              "deepest-group",
+						UUID:                 "member-uuid",
+						Roles:                database.TextArray[string]{"role-1", "role-2"},
+						InheritedFromGroupID: "root-group",
+						PreferredLoginName:   "gigi@caos-ag.zitadel.ch",
+						Email:                "gigi@caos.ch",
+						FirstName:            "first-name",
+						LastName:             "last-name",
+						DisplayName:          "display name",
+						AvatarURL:            "",
+						UserType:             domain.UserTypeHuman,
+					},
+				},
+			},
+		},
+		{
+			name: "prepareGroupMembersRecursiveQuery ids-only no-roles skips user joins",
+			prepare: func(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+				return prepareGroupMembersRecursiveQuery(ctx, db, "root-group", "instance-id", false, false)
+			},
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersRecursiveIDsOnlyNoRolesQuery,
+					groupMembersRecursiveIDsOnlyNoRolesColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"child-group",
+							"member-uuid",
+							int64(1),
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupMembers: []*GroupMember{
+					{
+						CreationDate:         testNow,
+						ChangeDate:           testNow,
+						Sequence:             20211206,
+						ResourceOwner:        "ro",
+						UserID:               "user-id",
+						GroupID:              "child-group",
+						UUID:                 "member-uuid",
+						InheritedFromGroupID: "root-group",
+					},
+				},
+			},
+		},
 		{
 			name:    "prepareGroupMembersQuery sql err",
 			prepare: prepareGroupMembersQuery,
@@ -302,4 +870,14 @@ func Test_GroupMemberPrepares(t *testing.T) {
 			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func Test_NewGroupMemberUUIDSearchQuery(t *testing.T) {
+	query, err := NewGroupMemberUUIDSearchQuery("member-uuid")
+	if err != nil {
+		t.Fatalf("NewGroupMemberUUIDSearchQuery should not error, got: %v", err)
+	}
+	if query == nil {
+		t.Fatal("NewGroupMemberUUIDSearchQuery should return a search query")
+	}
+}
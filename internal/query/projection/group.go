@@ -0,0 +1,109 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	GroupProjectionTable = "projections.groups"
+
+	GroupCreationDate  = "creation_date"
+	GroupChangeDate    = "change_date"
+	GroupSequence      = "sequence"
+	GroupResourceOwner = "resource_owner"
+	GroupInstanceIDCol = "instance_id"
+	GroupIDCol         = "id"
+	GroupNameCol       = "name"
+	GroupStateCol      = "state"
+	// GroupUUIDCol mirrors GroupMemberUUIDCol: a stable identifier generated
+	// once when the group is created, which external systems can rely on
+	// even if the group is later renamed.
+	GroupUUIDCol = "uuid"
+)
+
+type groupProjection struct{}
+
+func newGroupProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &groupProjection{})
+}
+
+func (*groupProjection) Name() string {
+	return GroupProjectionTable
+}
+
+func (p *groupProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  group.AddedEventType,
+					Reduce: p.reduceGroupAdded,
+				},
+				{
+					Event:  group.ChangedEventType,
+					Reduce: p.reduceGroupChanged,
+				},
+				{
+					Event:  group.RemovedEventType,
+					Reduce: p.reduceGroupRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *groupProjection) reduceGroupAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.AddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gr1Ad", "reduce.wrong.event.type %s", group.AddedEventType)
+	}
+	return handler.NewCreateStatement(e, []handler.Column{
+		handler.NewCol(GroupIDCol, e.Aggregate().ID),
+		handler.NewCol(GroupUUIDCol, e.UUID),
+		handler.NewCol(GroupCreationDate, e.CreationDate()),
+		handler.NewCol(GroupChangeDate, e.CreationDate()),
+		handler.NewCol(GroupSequence, e.Sequence()),
+		handler.NewCol(GroupResourceOwner, e.Aggregate().ResourceOwner),
+		handler.NewCol(GroupInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(GroupNameCol, e.Name),
+	}), nil
+}
+
+func (p *groupProjection) reduceGroupChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.ChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gr2Ad", "reduce.wrong.event.type %s", group.ChangedEventType)
+	}
+	cols := []handler.Column{
+		handler.NewCol(GroupChangeDate, e.CreationDate()),
+		handler.NewCol(GroupSequence, e.Sequence()),
+	}
+	if e.Name != nil {
+		cols = append(cols, handler.NewCol(GroupNameCol, *e.Name))
+	}
+	return handler.NewUpdateStatement(e, cols,
+		[]handler.Condition{
+			handler.NewCond(GroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceGroupRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.RemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gr3Ad", "reduce.wrong.event.type %s", group.RemovedEventType)
+	}
+	return handler.NewDeleteStatement(e,
+		[]handler.Condition{
+			handler.NewCond(GroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
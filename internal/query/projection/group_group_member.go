@@ -0,0 +1,85 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	GroupGroupMembersProjectionTable = "projections.group_group_members"
+
+	GroupGroupMembersCreationDateCol  = "creation_date"
+	GroupGroupMembersChangeDateCol    = "change_date"
+	GroupGroupMembersSequenceCol      = "sequence"
+	GroupGroupMembersResourceOwnerCol = "resource_owner"
+	GroupGroupMembersInstanceIDCol    = "instance_id"
+	GroupGroupMembersParentGroupIDCol = "parent_group_id"
+	GroupGroupMembersChildGroupIDCol  = "child_group_id"
+)
+
+// groupGroupMemberProjection reduces group-in-group nesting events into
+// projections.group_group_members, the table prepareGroupMembersRecursiveQuery
+// (see query.GroupMembersQuery.Recursive) walks to resolve transitive group
+// membership. It is distinct from groupMemberProjection, which reduces a
+// user being added to a group.
+type groupGroupMemberProjection struct{}
+
+func newGroupGroupMemberProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &groupGroupMemberProjection{})
+}
+
+func (*groupGroupMemberProjection) Name() string {
+	return GroupGroupMembersProjectionTable
+}
+
+func (p *groupGroupMemberProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  group.NestedGroupAddedEventType,
+					Reduce: p.reduceNestedGroupAdded,
+				},
+				{
+					Event:  group.NestedGroupRemovedEventType,
+					Reduce: p.reduceNestedGroupRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *groupGroupMemberProjection) reduceNestedGroupAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.NestedGroupAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gg1Ad", "reduce.wrong.event.type %s", group.NestedGroupAddedEventType)
+	}
+	return handler.NewCreateStatement(e, []handler.Column{
+		handler.NewCol(GroupGroupMembersCreationDateCol, e.CreationDate()),
+		handler.NewCol(GroupGroupMembersChangeDateCol, e.CreationDate()),
+		handler.NewCol(GroupGroupMembersSequenceCol, e.Sequence()),
+		handler.NewCol(GroupGroupMembersResourceOwnerCol, e.Aggregate().ResourceOwner),
+		handler.NewCol(GroupGroupMembersInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(GroupGroupMembersParentGroupIDCol, e.Aggregate().ID),
+		handler.NewCol(GroupGroupMembersChildGroupIDCol, e.ChildGroupID),
+	}), nil
+}
+
+func (p *groupGroupMemberProjection) reduceNestedGroupRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.NestedGroupRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gg2Rm", "reduce.wrong.event.type %s", group.NestedGroupRemovedEventType)
+	}
+	return handler.NewDeleteStatement(e,
+		[]handler.Condition{
+			handler.NewCond(GroupGroupMembersParentGroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupGroupMembersChildGroupIDCol, e.ChildGroupID),
+			handler.NewCond(GroupGroupMembersInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -0,0 +1,113 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	GroupMemberProjectionTable = "projections.group_members"
+
+	GroupMemberCreationDate  = "creation_date"
+	GroupMemberChangeDate    = "change_date"
+	GroupMemberSequence      = "sequence"
+	GroupMemberResourceOwner = "resource_owner"
+	GroupMemberInstanceIDCol = "instance_id"
+	GroupMemberUserIDCol     = "user_id"
+	GroupMemberGroupIDCol    = "group_id"
+	// GroupMemberUUIDCol is generated once, when the membership row is first
+	// created, and never changes afterwards: it is the stable handle SCIM
+	// clients, audit logs and IdP sync jobs can rely on even if the
+	// membership is later updated. Named to match GroupUUIDCol, the
+	// equivalent column on projections.groups.
+	GroupMemberUUIDCol  = "uuid"
+	GroupMemberRolesCol = "roles"
+)
+
+type groupMemberProjection struct{}
+
+func newGroupMemberProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &groupMemberProjection{})
+}
+
+func (*groupMemberProjection) Name() string {
+	return GroupMemberProjectionTable
+}
+
+func (p *groupMemberProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  group.MemberAddedEventType,
+					Reduce: p.reduceMemberAdded,
+				},
+				{
+					Event:  group.MemberChangedEventType,
+					Reduce: p.reduceMemberChanged,
+				},
+				{
+					Event:  group.MemberRemovedEventType,
+					Reduce: p.reduceMemberRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *groupMemberProjection) reduceMemberAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gr1Mb", "reduce.wrong.event.type %s", group.MemberAddedEventType)
+	}
+	return handler.NewCreateStatement(e, []handler.Column{
+		handler.NewCol(GroupMemberUUIDCol, e.UUID),
+		handler.NewCol(GroupMemberCreationDate, e.CreationDate()),
+		handler.NewCol(GroupMemberChangeDate, e.CreationDate()),
+		handler.NewCol(GroupMemberSequence, e.Sequence()),
+		handler.NewCol(GroupMemberResourceOwner, e.Aggregate().ResourceOwner),
+		handler.NewCol(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(GroupMemberUserIDCol, e.UserID),
+		handler.NewCol(GroupMemberGroupIDCol, e.Aggregate().ID),
+		handler.NewCol(GroupMemberRolesCol, database.TextArray[string](e.Roles)),
+	}), nil
+}
+
+func (p *groupMemberProjection) reduceMemberChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gr2Mb", "reduce.wrong.event.type %s", group.MemberChangedEventType)
+	}
+	return handler.NewUpdateStatement(e,
+		[]handler.Column{
+			handler.NewCol(GroupMemberChangeDate, e.CreationDate()),
+			handler.NewCol(GroupMemberSequence, e.Sequence()),
+			handler.NewCol(GroupMemberRolesCol, database.TextArray[string](e.Roles)),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupMemberUserIDCol, e.UserID),
+			handler.NewCond(GroupMemberGroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupMemberProjection) reduceMemberRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-gr3Mb", "reduce.wrong.event.type %s", group.MemberRemovedEventType)
+	}
+	return handler.NewDeleteStatement(e,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberUserIDCol, e.UserID),
+			handler.NewCond(GroupMemberGroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
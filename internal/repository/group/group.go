@@ -0,0 +1,61 @@
+package group
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix = eventstore.EventType("group.")
+
+	AddedEventType   = eventTypePrefix + "added"
+	ChangedEventType = eventTypePrefix + "changed"
+	RemovedEventType = eventTypePrefix + "removed"
+)
+
+const AggregateType = "group"
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// UUID is generated once, here at event creation time, and never
+	// touched again. Generating it in the projection reducer instead would
+	// make the value non-deterministic across projection rebuilds, since
+	// reduce functions are replayed verbatim from the eventstore.
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+func NewAddedEvent(ctx context.Context, aggregate *eventstore.Aggregate, name string) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, AddedEventType),
+		UUID:      uuid.New().String(),
+		Name:      name,
+	}
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name *string `json:"name,omitempty"`
+}
+
+func NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, name *string) *ChangedEvent {
+	return &ChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, ChangedEventType),
+		Name:      name,
+	}
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, RemovedEventType),
+	}
+}
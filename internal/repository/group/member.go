@@ -0,0 +1,65 @@
+package group
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	MemberAddedEventType   = eventTypePrefix + "member.added"
+	MemberChangedEventType = eventTypePrefix + "member.changed"
+	MemberRemovedEventType = eventTypePrefix + "member.removed"
+)
+
+type MemberAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// UUID is generated once, here at event creation time, rather than in
+	// the projection reducer, so the value stays the same across
+	// projection rebuilds (reduce functions are replayed verbatim from the
+	// eventstore and must be deterministic).
+	UUID   string                     `json:"uuid"`
+	UserID string                     `json:"userId"`
+	Roles  database.TextArray[string] `json:"roles,omitempty"`
+}
+
+func NewMemberAddedEvent(ctx context.Context, aggregate *eventstore.Aggregate, userID string, roles database.TextArray[string]) *MemberAddedEvent {
+	return &MemberAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, MemberAddedEventType),
+		UUID:      uuid.New().String(),
+		UserID:    userID,
+		Roles:     roles,
+	}
+}
+
+type MemberChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string                     `json:"userId"`
+	Roles  database.TextArray[string] `json:"roles,omitempty"`
+}
+
+func NewMemberChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, userID string, roles database.TextArray[string]) *MemberChangedEvent {
+	return &MemberChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, MemberChangedEventType),
+		UserID:    userID,
+		Roles:     roles,
+	}
+}
+
+type MemberRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string `json:"userId"`
+}
+
+func NewMemberRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, userID string) *MemberRemovedEvent {
+	return &MemberRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, MemberRemovedEventType),
+		UserID:    userID,
+	}
+}
@@ -0,0 +1,41 @@
+package group
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	NestedGroupAddedEventType   = eventTypePrefix + "nestedgroup.added"
+	NestedGroupRemovedEventType = eventTypePrefix + "nestedgroup.removed"
+)
+
+// NestedGroupAddedEvent records that ChildGroupID was added as a member of
+// the aggregate group, i.e. group-in-group nesting, as opposed to a user
+// being added to a group (see MemberAddedEvent).
+type NestedGroupAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChildGroupID string `json:"childGroupId"`
+}
+
+func NewNestedGroupAddedEvent(ctx context.Context, aggregate *eventstore.Aggregate, childGroupID string) *NestedGroupAddedEvent {
+	return &NestedGroupAddedEvent{
+		BaseEvent:    *eventstore.NewBaseEventForPush(ctx, aggregate, NestedGroupAddedEventType),
+		ChildGroupID: childGroupID,
+	}
+}
+
+type NestedGroupRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChildGroupID string `json:"childGroupId"`
+}
+
+func NewNestedGroupRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, childGroupID string) *NestedGroupRemovedEvent {
+	return &NestedGroupRemovedEvent{
+		BaseEvent:    *eventstore.NewBaseEventForPush(ctx, aggregate, NestedGroupRemovedEventType),
+		ChildGroupID: childGroupID,
+	}
+}